@@ -0,0 +1,130 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotFile is the on-disk shape written/read by SnapshotCache.
+type snapshotFile struct {
+	SavedAt time.Time `json:"saved_at"`
+	Games   []Game    `json:"games"`
+}
+
+// SnapshotCache persists the merged catalog to a gzipped JSON file so the
+// app has something to serve on a cold start even if every upstream is
+// down, and survives restarts without re-downloading everything.
+type SnapshotCache struct {
+	path string
+}
+
+// newSnapshotCache builds a cache rooted at $CACHE_DIR (default the working
+// directory), writing to games.json.gz within it.
+func newSnapshotCache() *SnapshotCache {
+	dir := os.Getenv("CACHE_DIR")
+	if dir == "" {
+		dir = "."
+	}
+	return &SnapshotCache{path: filepath.Join(dir, "games.json.gz")}
+}
+
+// Load reads the snapshot from disk. A missing or corrupt file is returned
+// as an error; callers should treat that as "start cold", not fatal.
+func (c *SnapshotCache) Load() ([]Game, time.Time, error) {
+	f, err := os.Open(c.path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer gz.Close()
+
+	var snap snapshotFile
+	if err := json.NewDecoder(gz).Decode(&snap); err != nil {
+		return nil, time.Time{}, err
+	}
+	return snap.Games, snap.SavedAt, nil
+}
+
+// Save writes games atomically: encode to a temp file in the same
+// directory, then rename over the real path so a crash mid-write can never
+// leave a truncated snapshot behind.
+func (c *SnapshotCache) Save(games []Game, savedAt time.Time) error {
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "games-*.json.gz.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	gz := gzip.NewWriter(tmp)
+	enc := json.NewEncoder(gz)
+	if err := enc.Encode(snapshotFile{SavedAt: savedAt, Games: games}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, c.path)
+}
+
+// Games returns a copy of the current catalog, safe to hand to the cache
+// writer without holding the store lock while it's encoded.
+func (s *GameStore) Games() []Game {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]Game, len(s.games))
+	copy(out, s.games)
+	return out
+}
+
+// LoadSnapshot seeds the store from a cold-start snapshot, before any
+// provider has run.
+func (s *GameStore) LoadSnapshot(games []Game, savedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.games = games
+	for _, g := range games {
+		if g.ID > s.nextID {
+			s.nextID = g.ID
+		}
+	}
+	s.snapshotAt = savedAt
+	s.rebuildIndexes()
+}
+
+// MarkSnapshotSaved records that the on-disk snapshot was just refreshed.
+func (s *GameStore) MarkSnapshotSaved(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshotAt = t
+}
+
+// SnapshotAge reports how long ago the current catalog was last persisted
+// to (or loaded from) the snapshot cache. ok is false if no snapshot has
+// ever been loaded or saved this run.
+func (s *GameStore) SnapshotAge() (age time.Duration, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.snapshotAt.IsZero() {
+		return 0, false
+	}
+	return time.Since(s.snapshotAt), true
+}
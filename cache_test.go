@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSnapshotCacheSaveLoadRoundTrip(t *testing.T) {
+	cache := &SnapshotCache{path: filepath.Join(t.TempDir(), "games.json.gz")}
+
+	games := []Game{
+		{ID: 1, Title: "Apex Legends", Genre: "Shooter", Sources: []string{"freetogame"}},
+		{ID: 2, Title: "Path of Exile", Genre: "ARPG"},
+	}
+	savedAt := time.Now().Truncate(time.Second)
+
+	if err := cache.Save(games, savedAt); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, gotSavedAt, err := cache.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(got) != len(games) {
+		t.Fatalf("Load returned %d games, want %d", len(got), len(games))
+	}
+	for i := range games {
+		if got[i].ID != games[i].ID || got[i].Title != games[i].Title {
+			t.Errorf("game %d = %+v, want %+v", i, got[i], games[i])
+		}
+	}
+	if !gotSavedAt.Equal(savedAt) {
+		t.Errorf("SavedAt = %v, want %v", gotSavedAt, savedAt)
+	}
+}
+
+func TestSnapshotCacheSaveIsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	cache := &SnapshotCache{path: filepath.Join(dir, "games.json.gz")}
+
+	if err := cache.Save([]Game{{ID: 1, Title: "First"}}, time.Now()); err != nil {
+		t.Fatalf("first Save: %v", err)
+	}
+	if err := cache.Save([]Game{{ID: 2, Title: "Second"}}, time.Now()); err != nil {
+		t.Fatalf("second Save: %v", err)
+	}
+
+	games, _, err := cache.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(games) != 1 || games[0].Title != "Second" {
+		t.Fatalf("Load = %+v, want only the second save's game (no leftover temp file content)", games)
+	}
+}
+
+func TestSnapshotCacheLoadMissingFile(t *testing.T) {
+	cache := &SnapshotCache{path: filepath.Join(t.TempDir(), "does-not-exist.json.gz")}
+	if _, _, err := cache.Load(); err == nil {
+		t.Fatalf("Load of a missing file should error so callers treat it as a cold start")
+	}
+}
+
+func TestEtagCacheAppliesAndCapturesValidators(t *testing.T) {
+	var e etagCache
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/games", nil)
+	e.applyTo(req)
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		t.Fatalf("applyTo should send no conditional headers before any validators are known")
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("ETag", `"abc123"`)
+	resp.Header.Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+	e.captureFrom(resp)
+
+	req2 := httptest.NewRequest(http.MethodGet, "https://example.com/games", nil)
+	e.applyTo(req2)
+	if got := req2.Header.Get("If-None-Match"); got != `"abc123"` {
+		t.Errorf("If-None-Match = %q, want the captured ETag", got)
+	}
+	if got := req2.Header.Get("If-Modified-Since"); got != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("If-Modified-Since = %q, want the captured Last-Modified", got)
+	}
+}
+
+func TestFreeToGameProviderTreats304AsNotModified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	// Run Fetch itself against the fake 304 upstream by rewriting the
+	// hardcoded production host onto the test server.
+	p := &FreeToGameProvider{client: &http.Client{Transport: rewriteHostTransport{target: target}}}
+
+	if _, err := p.Fetch(context.Background()); !errors.Is(err, ErrNotModified) {
+		t.Fatalf("Fetch error = %v, want ErrNotModified for a 304 response", err)
+	}
+}
+
+// rewriteHostTransport sends every request to target's scheme and host,
+// keeping the original path and headers.
+type rewriteHostTransport struct {
+	target *url.URL
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
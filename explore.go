@@ -0,0 +1,295 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rebuildIndexes recomputes the inverted indexes used by /explore. Callers
+// must hold s.mu for writing.
+func (s *GameStore) rebuildIndexes() {
+	s.genreIndex = make(map[string][]int)
+	s.platformIndex = make(map[string][]int)
+	s.publisherIndex = make(map[string][]int)
+	s.titleTrigrams = make(map[string][]int)
+
+	for i, g := range s.games {
+		s.genreIndex[normalizeFacet(g.Genre)] = append(s.genreIndex[normalizeFacet(g.Genre)], i)
+		s.platformIndex[normalizeFacet(g.Platform)] = append(s.platformIndex[normalizeFacet(g.Platform)], i)
+		s.publisherIndex[normalizeFacet(g.Publisher)] = append(s.publisherIndex[normalizeFacet(g.Publisher)], i)
+		for _, tri := range titleTrigrams(g.Title) {
+			s.titleTrigrams[tri] = append(s.titleTrigrams[tri], i)
+		}
+	}
+}
+
+func normalizeFacet(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// titleTrigrams returns the deduplicated set of 3-rune substrings of the
+// lowercased title, used to build the /explore title-search index.
+func titleTrigrams(title string) []string {
+	t := strings.ToLower(title)
+	runes := []rune(t)
+	if len(runes) < 3 {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var out []string
+	for i := 0; i+3 <= len(runes); i++ {
+		tri := string(runes[i : i+3])
+		if !seen[tri] {
+			seen[tri] = true
+			out = append(out, tri)
+		}
+	}
+	return out
+}
+
+// ExploreQuery holds the parsed query parameters for /explore.
+type ExploreQuery struct {
+	Genre     string
+	Platform  string
+	Publisher string
+	Q         string
+	YearFrom  int
+	YearTo    int
+	Sort      string
+	Page      int
+	PageSize  int
+}
+
+// ExploreFacets holds aggregate counts over the filtered result set.
+type ExploreFacets struct {
+	Genre     map[string]int `json:"genre"`
+	Platform  map[string]int `json:"platform"`
+	Publisher map[string]int `json:"publisher"`
+	Year      map[string]int `json:"year"`
+}
+
+// ExploreResponse is the JSON payload returned by /explore.
+type ExploreResponse struct {
+	Games    []Game        `json:"games"`
+	Facets   ExploreFacets `json:"facets"`
+	Total    int           `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+}
+
+func parseExploreQuery(c *gin.Context) ExploreQuery {
+	q := ExploreQuery{
+		Genre:     c.Query("genre"),
+		Platform:  c.Query("platform"),
+		Publisher: c.Query("publisher"),
+		Q:         c.Query("q"),
+		Sort:      c.Query("sort"),
+		Page:      1,
+		PageSize:  20,
+	}
+	if v, err := strconv.Atoi(c.Query("year_from")); err == nil {
+		q.YearFrom = v
+	}
+	if v, err := strconv.Atoi(c.Query("year_to")); err == nil {
+		q.YearTo = v
+	}
+	if v, err := strconv.Atoi(c.Query("page")); err == nil && v > 0 {
+		q.Page = v
+	}
+	if v, err := strconv.Atoi(c.Query("page_size")); err == nil && v > 0 && v <= 100 {
+		q.PageSize = v
+	}
+	return q
+}
+
+// explore runs q against the store's inverted indexes and returns the
+// matching indexes plus facet counts computed over them. Callers must hold
+// s.mu for reading.
+func (s *GameStore) explore(q ExploreQuery) ([]int, ExploreFacets) {
+	var candidates []int
+	filtered := false
+
+	if q.Genre != "" {
+		candidates = intersectIndexes(candidates, filtered, s.genreIndex[normalizeFacet(q.Genre)])
+		filtered = true
+	}
+	if q.Platform != "" {
+		candidates = intersectIndexes(candidates, filtered, s.platformIndex[normalizeFacet(q.Platform)])
+		filtered = true
+	}
+	if q.Publisher != "" {
+		candidates = intersectIndexes(candidates, filtered, s.publisherIndex[normalizeFacet(q.Publisher)])
+		filtered = true
+	}
+	if q.Q != "" {
+		candidates = intersectIndexes(candidates, filtered, s.titleCandidates(q.Q))
+		filtered = true
+	}
+
+	if !filtered {
+		candidates = make([]int, len(s.games))
+		for i := range s.games {
+			candidates[i] = i
+		}
+	}
+
+	facets := ExploreFacets{
+		Genre:     map[string]int{},
+		Platform:  map[string]int{},
+		Publisher: map[string]int{},
+		Year:      map[string]int{},
+	}
+
+	var matched []int
+	for _, i := range candidates {
+		g := s.games[i]
+		if q.Q != "" && !strings.Contains(strings.ToLower(g.Title), strings.ToLower(q.Q)) {
+			continue
+		}
+		if year, ok := releaseYear(g.ReleaseDate); ok {
+			if q.YearFrom != 0 && year < q.YearFrom {
+				continue
+			}
+			if q.YearTo != 0 && year > q.YearTo {
+				continue
+			}
+		}
+
+		matched = append(matched, i)
+		// Keyed by normalizeFacet so providers that disagree on casing
+		// (e.g. "Shooter" vs "shooter") roll up into one facet bucket,
+		// matching how the filters and indexes above already compare.
+		facets.Genre[normalizeFacet(g.Genre)]++
+		facets.Platform[normalizeFacet(g.Platform)]++
+		facets.Publisher[normalizeFacet(g.Publisher)]++
+		if year, ok := releaseYear(g.ReleaseDate); ok {
+			facets.Year[strconv.Itoa(year)]++
+		}
+	}
+
+	return matched, facets
+}
+
+// titleCandidates returns the candidate game indexes whose title contains
+// every trigram of q, via the titleTrigrams index. For queries shorter than
+// 3 runes the index can't help, so every game is returned as a candidate
+// and the caller falls back to a plain substring check.
+func (s *GameStore) titleCandidates(q string) []int {
+	trigrams := titleTrigrams(q)
+	if len(trigrams) == 0 {
+		all := make([]int, len(s.games))
+		for i := range s.games {
+			all[i] = i
+		}
+		return all
+	}
+
+	var result []int
+	for i, tri := range trigrams {
+		postings := s.titleTrigrams[tri]
+		if i == 0 {
+			result = postings
+			continue
+		}
+		result = intersectSorted(result, postings)
+		if len(result) == 0 {
+			break
+		}
+	}
+	return result
+}
+
+func intersectIndexes(acc []int, hasAcc bool, next []int) []int {
+	if !hasAcc {
+		out := make([]int, len(next))
+		copy(out, next)
+		sort.Ints(out)
+		return out
+	}
+	return intersectSorted(acc, sortedCopy(next))
+}
+
+func sortedCopy(in []int) []int {
+	out := make([]int, len(in))
+	copy(out, in)
+	sort.Ints(out)
+	return out
+}
+
+// intersectSorted returns the intersection of two sorted, duplicate-free
+// index slices in O(len(a)+len(b)).
+func intersectSorted(a, b []int) []int {
+	var out []int
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, a[i])
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return out
+}
+
+// releaseYear extracts the year from a "YYYY-MM-DD" release date.
+func releaseYear(date string) (int, bool) {
+	if len(date) < 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0, false
+	}
+	return year, true
+}
+
+func sortGames(games []Game, by string) {
+	switch by {
+	case "title":
+		sort.Slice(games, func(i, j int) bool { return games[i].Title < games[j].Title })
+	case "publisher":
+		sort.Slice(games, func(i, j int) bool { return games[i].Publisher < games[j].Publisher })
+	case "release_date":
+		sort.Slice(games, func(i, j int) bool { return games[i].ReleaseDate < games[j].ReleaseDate })
+	}
+}
+
+func exploreHandler(c *gin.Context) {
+	q := parseExploreQuery(c)
+
+	store.mu.RLock()
+	matched, facets := store.explore(q)
+	games := make([]Game, 0, len(matched))
+	for _, i := range matched {
+		games = append(games, store.games[i])
+	}
+	store.mu.RUnlock()
+
+	sortGames(games, q.Sort)
+
+	total := len(games)
+	start := (q.Page - 1) * q.PageSize
+	if start > total {
+		start = total
+	}
+	end := start + q.PageSize
+	if end > total {
+		end = total
+	}
+
+	c.JSON(200, ExploreResponse{
+		Games:    games[start:end],
+		Facets:   facets,
+		Total:    total,
+		Page:     q.Page,
+		PageSize: q.PageSize,
+	})
+}
@@ -0,0 +1,101 @@
+package main
+
+import "testing"
+
+func TestTitleTrigrams(t *testing.T) {
+	if got := titleTrigrams("ab"); got != nil {
+		t.Fatalf("titleTrigrams(%q) = %v, want nil for strings shorter than 3 runes", "ab", got)
+	}
+
+	got := titleTrigrams("Abcde")
+	want := []string{"abc", "bcd", "cde"}
+	if len(got) != len(want) {
+		t.Fatalf("titleTrigrams(%q) = %v, want %v", "Abcde", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("titleTrigrams(%q)[%d] = %q, want %q", "Abcde", i, got[i], want[i])
+		}
+	}
+}
+
+func TestIntersectSorted(t *testing.T) {
+	cases := []struct {
+		a, b, want []int
+	}{
+		{[]int{1, 2, 3}, []int{2, 3, 4}, []int{2, 3}},
+		{[]int{1, 2, 3}, []int{4, 5, 6}, nil},
+		{nil, []int{1, 2}, nil},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, []int{1, 2, 3}},
+	}
+	for _, tc := range cases {
+		got := intersectSorted(tc.a, tc.b)
+		if len(got) != len(tc.want) {
+			t.Fatalf("intersectSorted(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+		for i := range tc.want {
+			if got[i] != tc.want[i] {
+				t.Errorf("intersectSorted(%v, %v)[%d] = %d, want %d", tc.a, tc.b, i, got[i], tc.want[i])
+			}
+		}
+	}
+}
+
+func testStore(games []Game) *GameStore {
+	s := &GameStore{}
+	s.SetGames(games)
+	return s
+}
+
+func TestGameStoreExploreFacetsNormalizeCasing(t *testing.T) {
+	s := testStore([]Game{
+		{ID: 1, Title: "Apex Legends", Genre: "Shooter", Platform: "PC", Publisher: "EA", ReleaseDate: "2019-02-04"},
+		{ID: 2, Title: "Fortnite", Genre: "shooter", Platform: "PC", Publisher: "Epic", ReleaseDate: "2017-07-25"},
+		{ID: 3, Title: "Chess Club", Genre: "Strategy", Platform: "Browser", Publisher: "Chess.com", ReleaseDate: "2007-01-01"},
+	})
+
+	s.mu.RLock()
+	matched, facets := s.explore(ExploreQuery{Genre: "SHOOTER"})
+	s.mu.RUnlock()
+
+	if len(matched) != 2 {
+		t.Fatalf("matched = %d games, want 2", len(matched))
+	}
+	if got := facets.Genre["shooter"]; got != 2 {
+		t.Errorf(`facets.Genre["shooter"] = %d, want 2 (differently-cased genres should roll into one bucket)`, got)
+	}
+	if _, ok := facets.Genre["Shooter"]; ok {
+		t.Errorf("facets.Genre has an un-normalized \"Shooter\" key alongside \"shooter\"")
+	}
+}
+
+func TestGameStoreExploreTitleSearch(t *testing.T) {
+	s := testStore([]Game{
+		{ID: 1, Title: "Apex Legends", Genre: "Shooter", Platform: "PC"},
+		{ID: 2, Title: "League of Legends", Genre: "MOBA", Platform: "PC"},
+		{ID: 3, Title: "Chess Club", Genre: "Strategy", Platform: "Browser"},
+	})
+
+	s.mu.RLock()
+	matched, _ := s.explore(ExploreQuery{Q: "legends"})
+	s.mu.RUnlock()
+
+	if len(matched) != 2 {
+		t.Fatalf("matched = %d games for q=legends, want 2", len(matched))
+	}
+}
+
+func TestGameStoreExploreYearRange(t *testing.T) {
+	s := testStore([]Game{
+		{ID: 1, Title: "Old Game", ReleaseDate: "2010-01-01"},
+		{ID: 2, Title: "New Game", ReleaseDate: "2022-01-01"},
+	})
+
+	s.mu.RLock()
+	matched, _ := s.explore(ExploreQuery{YearFrom: 2020})
+	s.mu.RUnlock()
+
+	if len(matched) != 1 || s.games[matched[0]].Title != "New Game" {
+		t.Fatalf("matched = %v, want only the 2022 game", matched)
+	}
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
-	"log"
+	"log/slog"
 	"math/rand"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,21 +17,29 @@ import (
 )
 
 type Game struct {
-	ID               int    `json:"id"`
-	Title            string `json:"title"`
-	Thumbnail        string `json:"thumbnail"`
-	ShortDescription string `json:"short_description"`
-	GameURL          string `json:"game_url"`
-	Genre            string `json:"genre"`
-	Platform         string `json:"platform"`
-	Publisher        string `json:"publisher"`
-	Developer        string `json:"developer"`
-	ReleaseDate      string `json:"release_date"`
+	ID               int      `json:"id"`
+	Title            string   `json:"title"`
+	Thumbnail        string   `json:"thumbnail"`
+	ShortDescription string   `json:"short_description"`
+	GameURL          string   `json:"game_url"`
+	Genre            string   `json:"genre"`
+	Platform         string   `json:"platform"`
+	Publisher        string   `json:"publisher"`
+	Developer        string   `json:"developer"`
+	ReleaseDate      string   `json:"release_date"`
+	Sources          []string `json:"sources,omitempty"`
 }
 
 type GameStore struct {
-	games []Game
-	mu    sync.RWMutex
+	games      []Game
+	nextID     int
+	snapshotAt time.Time
+	mu         sync.RWMutex
+
+	genreIndex     map[string][]int
+	platformIndex  map[string][]int
+	publisherIndex map[string][]int
+	titleTrigrams  map[string][]int
 }
 
 var store = &GameStore{}
@@ -38,6 +48,71 @@ func (s *GameStore) SetGames(games []Game) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.games = games
+	for _, g := range games {
+		if g.ID > s.nextID {
+			s.nextID = g.ID
+		}
+	}
+	s.rebuildIndexes()
+}
+
+// MergeProvider merges incoming games from a named provider into the store.
+// Games are deduplicated by normalized title+platform: a repeat match just
+// gains the provider's name in its Sources, a new one is appended and given
+// a synthetic ID if it has none or its ID is already taken by another game
+// in the store. Safe to call concurrently
+// from multiple provider loops.
+func (s *GameStore) MergeProvider(source string, incoming []Game) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := make(map[string]int, len(s.games))
+	for i, g := range s.games {
+		existing[dedupeKey(g)] = i
+	}
+
+	for _, g := range incoming {
+		k := dedupeKey(g)
+		if idx, ok := existing[k]; ok {
+			s.games[idx].Sources = appendSource(s.games[idx].Sources, source)
+			continue
+		}
+		if g.ID == 0 || s.idTaken(g.ID) {
+			s.nextID++
+			g.ID = s.nextID
+		} else if g.ID > s.nextID {
+			s.nextID = g.ID
+		}
+		g.Sources = appendSource(g.Sources, source)
+		s.games = append(s.games, g)
+		existing[k] = len(s.games) - 1
+	}
+
+	s.rebuildIndexes()
+}
+
+// idTaken reports whether id is already used by another game in the store.
+// Called with s.mu held.
+func (s *GameStore) idTaken(id int) bool {
+	for _, g := range s.games {
+		if g.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupeKey(g Game) string {
+	return normalizeFacet(g.Title) + "|" + normalizeFacet(g.Platform)
+}
+
+func appendSource(sources []string, source string) []string {
+	for _, s := range sources {
+		if s == source {
+			return sources
+		}
+	}
+	return append(sources, source)
 }
 
 func (s *GameStore) GetRandom() (Game, bool) {
@@ -49,60 +124,91 @@ func (s *GameStore) GetRandom() (Game, bool) {
 	return s.games[rand.Intn(len(s.games))], true
 }
 
-func (s *GameStore) Count() int {
+// GetRandomFiltered returns a random game matching filters whose id is not
+// in seen, or false if nothing qualifies. An empty filter field matches any
+// value.
+func (s *GameStore) GetRandomFiltered(filters wsFilters, seen map[int]bool) (Game, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.games)
-}
 
-func fetchGames() error {
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Get("https://www.freetogame.com/api/games")
-	if err != nil {
-		return err
+	var candidates []Game
+	for _, g := range s.games {
+		if seen[g.ID] {
+			continue
+		}
+		if filters.Genre != "" && !strings.EqualFold(g.Genre, filters.Genre) {
+			continue
+		}
+		if filters.Platform != "" && !strings.Contains(strings.ToLower(g.Platform), strings.ToLower(filters.Platform)) {
+			continue
+		}
+		candidates = append(candidates, g)
 	}
-	defer resp.Body.Close()
+	if len(candidates) == 0 {
+		return Game{}, false
+	}
+	return candidates[rand.Intn(len(candidates))], true
+}
 
-	var games []Game
-	if err := json.NewDecoder(resp.Body).Decode(&games); err != nil {
-		return err
+// GetByID returns the game with the given ID, or false if none matches.
+func (s *GameStore) GetByID(id int) (Game, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, g := range s.games {
+		if g.ID == id {
+			return g, true
+		}
 	}
+	return Game{}, false
+}
 
-	store.SetGames(games)
-	log.Printf("✅ Loaded %d games from FreeToGame API", len(games))
-	return nil
+func (s *GameStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.games)
 }
 
 func main() {
+	mode := os.Getenv("GIN_MODE")
+	if mode == "" {
+		mode = gin.ReleaseMode
+	}
+	gin.SetMode(mode)
+	initLogger()
+
 	rand.Seed(time.Now().UnixNano())
 
-	if err := fetchGames(); err != nil {
-		log.Printf("⚠️ Warning: Could not fetch games: %v", err)
+	cache := newSnapshotCache()
+	if games, savedAt, err := cache.Load(); err == nil {
+		store.LoadSnapshot(games, savedAt)
+		gamesInStore.Set(float64(len(games)))
+		slog.Info("loaded games from snapshot cache", "count", len(games), "age", time.Since(savedAt))
+	} else {
+		slog.Info("no snapshot cache loaded", "error", err)
 	}
 
-	// Refresh games periodically
-	go func() {
-		ticker := time.NewTicker(1 * time.Hour)
-		for range ticker.C {
-			if err := fetchGames(); err != nil {
-				log.Printf("⚠️ Refresh failed: %v", err)
-			}
-		}
-	}()
+	manager := newProviderManager(store, cache, defaultProviders()...)
+	manager.Start(context.Background())
 
-	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
 
 	r.GET("/", indexHandler)
 	r.GET("/stream", streamHandler)
 	r.GET("/stats", statsHandler)
+	r.GET("/explore", exploreHandler)
+	r.GET("/ws", wsHandler)
+	r.GET("/providers", manager.Handler)
+	r.POST("/feedback", feedbackHandler)
+	r.GET("/metrics", metricsHandler())
+	r.GET("/healthz", healthzHandler)
+	r.GET("/readyz", readyzHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
-	log.Printf("🎮 Game Explorer running on http://localhost:%s", port)
+	slog.Info("game explorer running", "port", port)
 	r.Run(":" + port)
 }
 
@@ -113,10 +219,14 @@ func indexHandler(c *gin.Context) {
 }
 
 func statsHandler(c *gin.Context) {
-	c.JSON(200, gin.H{
+	stats := gin.H{
 		"total_games": store.Count(),
 		"status":      "online",
-	})
+	}
+	if age, ok := store.SnapshotAge(); ok {
+		stats["snapshot_age_seconds"] = age.Seconds()
+	}
+	c.JSON(200, stats)
 }
 
 func streamHandler(c *gin.Context) {
@@ -132,16 +242,43 @@ func streamHandler(c *gin.Context) {
 		return
 	}
 
+	var profile *SessionProfile
+	if c.Query("mode") == "recommend" {
+		profile = sessions.GetOrCreate(sessionIDFromRequest(c))
+	}
+
+	sseClientsConnected.Inc()
+	connectedAt := time.Now()
+	defer func() {
+		sseClientsConnected.Dec()
+		sseClientLifetimeSeconds.Observe(time.Since(connectedAt).Seconds())
+	}()
+
 	sendGame := func() {
-		game, ok := store.GetRandom()
+		lookupStart := time.Now()
+		var game Game
+		var ok bool
+		if profile != nil {
+			game, ok = recommendNext(profile)
+		} else {
+			game, ok = store.GetRandom()
+		}
+		lookupDuration := time.Since(lookupStart)
+
 		if !ok {
 			fmt.Fprintf(c.Writer, "event: error\ndata: {\"message\":\"No games available\"}\n\n")
 			flusher.Flush()
 			return
 		}
 		data, _ := json.Marshal(game)
+		// A real HTTP trailer can only be sent once, at the end of the
+		// response, which doesn't fit a long-lived SSE connection — so the
+		// per-event Server-Timing value rides along as an SSE comment line
+		// instead, which clients and browser devtools alike ignore safely.
+		fmt.Fprintf(c.Writer, ": Server-Timing: store;dur=%.3f\n", lookupDuration.Seconds()*1000)
 		fmt.Fprintf(c.Writer, "data: %s\n\n", data)
 		flusher.Flush()
+		sseEventsSentTotal.Inc()
 	}
 
 	// Send first game immediately
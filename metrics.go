@@ -0,0 +1,80 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	gamesFetchTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "games_fetch_total",
+		Help: "Count of provider fetch attempts, labeled by outcome.",
+	}, []string{"status"})
+
+	sseClientsConnected = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sse_clients_connected",
+		Help: "Number of currently connected /stream SSE clients.",
+	})
+
+	sseEventsSentTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "sse_events_sent_total",
+		Help: "Total number of games pushed to SSE clients.",
+	})
+
+	fetchDurationSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "fetch_duration_seconds",
+		Help: "Provider fetch latency in seconds.",
+	}, []string{"provider"})
+
+	sseClientLifetimeSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "sse_client_lifetime_seconds",
+		Help: "Duration an SSE client stayed connected to /stream, in seconds.",
+	})
+
+	gamesInStore = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "games_in_store",
+		Help: "Number of games currently held in the GameStore.",
+	})
+)
+
+func metricsHandler() gin.HandlerFunc {
+	h := promhttp.Handler()
+	return gin.WrapH(h)
+}
+
+// initLogger points the package-level slog default at JSON output in
+// release mode, for log aggregation, and plain key/value pairs otherwise.
+func initLogger() {
+	var handler slog.Handler
+	if gin.Mode() == gin.ReleaseMode {
+		handler = slog.NewJSONHandler(os.Stdout, nil)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, nil)
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+// healthzHandler is liveness: the process is up and serving requests.
+func healthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "alive"})
+}
+
+// readyzHandler is readiness: only ready once there's something to serve,
+// either from a provider fetch or a loaded cold-start snapshot.
+func readyzHandler(c *gin.Context) {
+	if store.Count() > 0 {
+		c.JSON(http.StatusOK, gin.H{"status": "ready"})
+		return
+	}
+	if _, ok := store.SnapshotAge(); ok {
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "source": "snapshot"})
+		return
+	}
+	c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready"})
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func withTestStore(t *testing.T, fn func()) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	original := store
+	store = &GameStore{}
+	defer func() { store = original }()
+	fn()
+}
+
+func TestReadyzNotReadyWithEmptyStoreAndNoSnapshot(t *testing.T) {
+	withTestStore(t, func() {
+		r := gin.New()
+		r.GET("/readyz", readyzHandler)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("status = %d, want %d for an empty store with no snapshot", w.Code, http.StatusServiceUnavailable)
+		}
+	})
+}
+
+func TestReadyzReadyOnceStoreHasGames(t *testing.T) {
+	withTestStore(t, func() {
+		store.SetGames([]Game{{ID: 1, Title: "A"}})
+
+		r := gin.New()
+		r.GET("/readyz", readyzHandler)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d once the store has games", w.Code, http.StatusOK)
+		}
+	})
+}
+
+func TestReadyzReadyFromSnapshotEvenWithoutFetchedGames(t *testing.T) {
+	withTestStore(t, func() {
+		store.LoadSnapshot(nil, time.Now())
+
+		r := gin.New()
+		r.GET("/readyz", readyzHandler)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d when a snapshot was loaded, even with zero games", w.Code, http.StatusOK)
+		}
+	})
+}
+
+func TestHealthzAlwaysAlive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/healthz", healthzHandler)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Provider is a source of games that can be polled on its own schedule and
+// merged into the shared GameStore.
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context) ([]Game, error)
+	RefreshInterval() time.Duration
+}
+
+// ErrNotModified is returned by a Provider's Fetch when a conditional
+// request confirms the upstream catalog hasn't changed. The manager treats
+// this as a successful, empty refresh rather than a failure.
+var ErrNotModified = errors.New("provider: not modified")
+
+// etagCache tracks the validators needed to make a conditional GET, so a
+// provider doesn't re-download an unchanged catalog every cycle.
+type etagCache struct {
+	etag         string
+	lastModified string
+}
+
+func (e *etagCache) applyTo(req *http.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
+
+func (e *etagCache) captureFrom(resp *http.Response) {
+	if v := resp.Header.Get("ETag"); v != "" {
+		e.etag = v
+	}
+	if v := resp.Header.Get("Last-Modified"); v != "" {
+		e.lastModified = v
+	}
+}
+
+// defaultProviders returns the providers wired into a normal run. The RSS
+// and local-file adapters are opt-in via env vars so the app still boots
+// with just the two HTTP catalogs when they're unset.
+func defaultProviders() []Provider {
+	providers := []Provider{
+		&FreeToGameProvider{client: &http.Client{Timeout: 30 * time.Second}},
+		&MMOBombProvider{client: &http.Client{Timeout: 30 * time.Second}},
+	}
+	if feedURL := os.Getenv("RSS_FEED_URL"); feedURL != "" {
+		providers = append(providers, &RSSProvider{client: &http.Client{Timeout: 30 * time.Second}, url: feedURL})
+	}
+	if path := os.Getenv("LOCAL_GAMES_FILE"); path != "" {
+		providers = append(providers, &LocalFileProvider{path: path})
+	}
+	return providers
+}
+
+// FreeToGameProvider fetches the full catalog from the FreeToGame API.
+type FreeToGameProvider struct {
+	client     *http.Client
+	validators etagCache
+}
+
+func (p *FreeToGameProvider) Name() string                  { return "freetogame" }
+func (p *FreeToGameProvider) RefreshInterval() time.Duration { return 1 * time.Hour }
+
+func (p *FreeToGameProvider) Fetch(ctx context.Context) ([]Game, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.freetogame.com/api/games", nil)
+	if err != nil {
+		return nil, err
+	}
+	p.validators.applyTo(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+	p.validators.captureFrom(resp)
+
+	var games []Game
+	if err := json.NewDecoder(resp.Body).Decode(&games); err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// MMOBombProvider fetches the catalog from the MMOBomb API and maps it onto
+// the shared Game shape.
+type MMOBombProvider struct {
+	client     *http.Client
+	validators etagCache
+}
+
+func (p *MMOBombProvider) Name() string                  { return "mmobomb" }
+func (p *MMOBombProvider) RefreshInterval() time.Duration { return 1 * time.Hour }
+
+type mmoBombGame struct {
+	ID               int    `json:"id"`
+	Title            string `json:"title"`
+	ThumbnailURL     string `json:"thumbnail"`
+	ShortDescription string `json:"short_description"`
+	ProfileURL       string `json:"profile_url"`
+	Genre            string `json:"genre"`
+	Platform         string `json:"platform"`
+	Publisher        string `json:"publisher"`
+	Developer        string `json:"developer"`
+	ReleaseDate      string `json:"release_date"`
+}
+
+func (p *MMOBombProvider) Fetch(ctx context.Context) ([]Game, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.mmobomb.com/api1/games", nil)
+	if err != nil {
+		return nil, err
+	}
+	p.validators.applyTo(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
+	p.validators.captureFrom(resp)
+
+	var raw []mmoBombGame
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	games := make([]Game, 0, len(raw))
+	for _, g := range raw {
+		games = append(games, Game{
+			Title:            g.Title,
+			Thumbnail:        g.ThumbnailURL,
+			ShortDescription: g.ShortDescription,
+			GameURL:          g.ProfileURL,
+			Genre:            g.Genre,
+			Platform:         g.Platform,
+			Publisher:        g.Publisher,
+			Developer:        g.Developer,
+			ReleaseDate:      g.ReleaseDate,
+		})
+	}
+	return games, nil
+}
+
+// RSSProvider adapts an RSS 2.0 feed of game announcements into Games.
+// Only <channel><item> elements are read; an Atom feed (<feed><entry>)
+// decodes without error but yields no games.
+// Feed entries rarely carry genre/platform/publisher, so those fields are
+// left blank rather than guessed.
+type RSSProvider struct {
+	client *http.Client
+	url    string
+}
+
+func (p *RSSProvider) Name() string                  { return "rss:" + p.url }
+func (p *RSSProvider) RefreshInterval() time.Duration { return 15 * time.Minute }
+
+type rssFeed struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+func (p *RSSProvider) Fetch(ctx context.Context) ([]Game, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var feed rssFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	games := make([]Game, 0, len(feed.Channel.Items))
+	for _, item := range feed.Channel.Items {
+		games = append(games, Game{
+			Title:            item.Title,
+			GameURL:          item.Link,
+			ShortDescription: item.Description,
+		})
+	}
+	return games, nil
+}
+
+// LocalFileProvider reads a JSON array of Games from disk, for offline use
+// and tests where hitting the real upstreams isn't desirable.
+type LocalFileProvider struct {
+	path string
+}
+
+func (p *LocalFileProvider) Name() string                  { return "local:" + p.path }
+func (p *LocalFileProvider) RefreshInterval() time.Duration { return 1 * time.Hour }
+
+func (p *LocalFileProvider) Fetch(ctx context.Context) ([]Game, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	var games []Game
+	if err := json.Unmarshal(data, &games); err != nil {
+		return nil, err
+	}
+	return games, nil
+}
+
+// circuitBreaker applies exponential backoff after consecutive failures so
+// one misbehaving provider doesn't get hammered or block the others.
+type circuitBreaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+const (
+	breakerBaseDelay = 10 * time.Second
+	breakerMaxDelay  = 30 * time.Minute
+)
+
+func (b *circuitBreaker) open() bool {
+	return time.Now().Before(b.openUntil)
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.failures++
+	delay := breakerBaseDelay * time.Duration(1<<uint(min(b.failures-1, 10)))
+	if delay > breakerMaxDelay {
+		delay = breakerMaxDelay
+	}
+	b.openUntil = time.Now().Add(delay)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// ProviderStatus is the reporting snapshot exposed over /providers.
+type ProviderStatus struct {
+	Name        string    `json:"name"`
+	LastSuccess time.Time `json:"last_success"`
+	ItemCount   int       `json:"item_count"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// ProviderManager schedules each Provider independently, merges successful
+// fetches into the store, and tracks per-provider health for /providers.
+type ProviderManager struct {
+	store     *GameStore
+	cache     *SnapshotCache
+	providers []Provider
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+	statuses map[string]ProviderStatus
+}
+
+func newProviderManager(store *GameStore, cache *SnapshotCache, providers ...Provider) *ProviderManager {
+	m := &ProviderManager{
+		store:     store,
+		cache:     cache,
+		providers: providers,
+		breakers:  make(map[string]*circuitBreaker),
+		statuses:  make(map[string]ProviderStatus),
+	}
+	for _, p := range providers {
+		m.breakers[p.Name()] = &circuitBreaker{}
+		m.statuses[p.Name()] = ProviderStatus{Name: p.Name()}
+	}
+	return m
+}
+
+// Start launches one polling loop per provider. Each loop fetches
+// immediately, then again every RefreshInterval, skipping attempts while
+// that provider's circuit breaker is open.
+func (m *ProviderManager) Start(ctx context.Context) {
+	for _, p := range m.providers {
+		go m.run(ctx, p)
+	}
+}
+
+func (m *ProviderManager) run(ctx context.Context, p Provider) {
+	m.attempt(ctx, p)
+
+	ticker := time.NewTicker(p.RefreshInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.attempt(ctx, p)
+		}
+	}
+}
+
+func (m *ProviderManager) attempt(ctx context.Context, p Provider) {
+	m.mu.Lock()
+	breaker := m.breakers[p.Name()]
+	if breaker.open() {
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+
+	fetchStart := time.Now()
+	fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	games, err := p.Fetch(fetchCtx)
+	cancel()
+	fetchDurationSeconds.WithLabelValues(p.Name()).Observe(time.Since(fetchStart).Seconds())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := m.statuses[p.Name()]
+
+	if errors.Is(err, ErrNotModified) {
+		breaker.recordSuccess()
+		status.LastSuccess = time.Now()
+		status.LastError = ""
+		m.statuses[p.Name()] = status
+		gamesFetchTotal.WithLabelValues("not_modified").Inc()
+		slog.Info("provider not modified", "provider", p.Name())
+		return
+	}
+	if err != nil {
+		breaker.recordFailure()
+		status.LastError = err.Error()
+		m.statuses[p.Name()] = status
+		gamesFetchTotal.WithLabelValues("error").Inc()
+		slog.Warn("provider fetch failed", "provider", p.Name(), "error", err)
+		return
+	}
+
+	breaker.recordSuccess()
+	status.LastSuccess = time.Now()
+	status.ItemCount = len(games)
+	status.LastError = ""
+	m.statuses[p.Name()] = status
+	gamesFetchTotal.WithLabelValues("success").Inc()
+
+	m.store.MergeProvider(p.Name(), games)
+	gamesInStore.Set(float64(m.store.Count()))
+	hub.BroadcastRefresh()
+	slog.Info("provider loaded games", "provider", p.Name(), "count", len(games))
+
+	if m.cache != nil {
+		now := time.Now()
+		if err := m.cache.Save(m.store.Games(), now); err != nil {
+			slog.Warn("snapshot save failed", "error", err)
+		} else {
+			m.store.MarkSnapshotSaved(now)
+		}
+	}
+}
+
+// Handler serves /providers: last-success time, item count, and error for
+// every registered provider.
+func (m *ProviderManager) Handler(c *gin.Context) {
+	m.mu.Lock()
+	out := make([]ProviderStatus, 0, len(m.statuses))
+	for _, s := range m.statuses {
+		out = append(out, s)
+	}
+	m.mu.Unlock()
+
+	c.JSON(200, gin.H{"providers": out})
+}
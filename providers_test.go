@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDedupeKeyNormalizesCaseAndSpace(t *testing.T) {
+	a := dedupeKey(Game{Title: " Apex Legends ", Platform: "PC"})
+	b := dedupeKey(Game{Title: "apex legends", Platform: "pc"})
+	if a != b {
+		t.Fatalf("dedupeKey mismatch for equivalent title/platform: %q vs %q", a, b)
+	}
+
+	c := dedupeKey(Game{Title: "Apex Legends", Platform: "PS4"})
+	if a == c {
+		t.Fatalf("dedupeKey should differ across platforms, got %q for both", a)
+	}
+}
+
+func TestMergeProviderDedupesAndTagsSources(t *testing.T) {
+	s := &GameStore{}
+	s.MergeProvider("freetogame", []Game{
+		{ID: 1, Title: "Apex Legends", Platform: "PC"},
+	})
+	s.MergeProvider("mmobomb", []Game{
+		{Title: "apex legends", Platform: "pc"}, // same game, no upstream ID
+		{Title: "Path of Exile", Platform: "PC"},
+	})
+
+	games := s.Games()
+	if len(games) != 2 {
+		t.Fatalf("got %d games after merge, want 2 (apex should dedupe)", len(games))
+	}
+
+	var apex *Game
+	for i := range games {
+		if dedupeKey(games[i]) == dedupeKey(Game{Title: "Apex Legends", Platform: "PC"}) {
+			apex = &games[i]
+		}
+	}
+	if apex == nil {
+		t.Fatalf("apex legends missing from merged store: %+v", games)
+	}
+	if len(apex.Sources) != 2 {
+		t.Errorf("apex.Sources = %v, want both freetogame and mmobomb", apex.Sources)
+	}
+}
+
+func TestMergeProviderAssignsSyntheticIDForNewGames(t *testing.T) {
+	s := &GameStore{}
+	s.MergeProvider("freetogame", []Game{{ID: 5, Title: "A", Platform: "PC"}})
+	s.MergeProvider("local:test", []Game{{Title: "B", Platform: "PC"}})
+
+	games := s.Games()
+	for _, g := range games {
+		if g.ID == 0 {
+			t.Errorf("game %+v kept a zero ID after merge", g)
+		}
+	}
+	if games[0].ID == games[1].ID {
+		t.Errorf("merged games share ID %d, want distinct IDs", games[0].ID)
+	}
+}
+
+func TestCircuitBreakerBacksOffExponentially(t *testing.T) {
+	b := &circuitBreaker{}
+	if b.open() {
+		t.Fatalf("fresh breaker should not be open")
+	}
+
+	b.recordFailure()
+	firstDelay := time.Until(b.openUntil)
+	if !b.open() {
+		t.Fatalf("breaker should be open immediately after a failure")
+	}
+
+	b.openUntil = time.Time{} // force-expire so the next failure is measured cleanly
+	b.recordFailure()
+	secondDelay := time.Until(b.openUntil)
+
+	if secondDelay <= firstDelay {
+		t.Fatalf("backoff didn't grow: failure 1 -> %s, failure 2 -> %s", firstDelay, secondDelay)
+	}
+}
+
+func TestCircuitBreakerCapsAtMaxDelay(t *testing.T) {
+	b := &circuitBreaker{}
+	for i := 0; i < 30; i++ {
+		b.recordFailure()
+	}
+	if delay := time.Until(b.openUntil); delay > breakerMaxDelay+time.Second {
+		t.Fatalf("backoff delay %s exceeds cap %s", delay, breakerMaxDelay)
+	}
+}
+
+func TestCircuitBreakerRecordSuccessResets(t *testing.T) {
+	b := &circuitBreaker{}
+	b.recordFailure()
+	b.recordFailure()
+	b.recordSuccess()
+
+	if b.failures != 0 {
+		t.Errorf("failures = %d after recordSuccess, want 0", b.failures)
+	}
+	if b.open() {
+		t.Errorf("breaker should not be open right after recordSuccess")
+	}
+}
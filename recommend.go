@@ -0,0 +1,290 @@
+package main
+
+import (
+	"container/list"
+	crand "crypto/rand"
+	"encoding/hex"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	sessionCookieName  = "session_id"
+	sessionTTL         = 24 * time.Hour
+	sessionMaxProfiles = 10000
+
+	recommendTemperature = 0.7
+	explorationRate      = 0.15
+)
+
+// signalWeights maps a /feedback signal to how strongly it nudges a
+// session's genre/platform/publisher preferences. Negative signals still
+// move the needle, but counts are clamped at zero so a single dislike can't
+// flip a dimension's normalization negative.
+var signalWeights = map[string]float64{
+	"like":    3,
+	"open":    1,
+	"skip":    -0.5,
+	"dislike": -2,
+}
+
+// SessionProfile is one visitor's accumulated content preferences, used to
+// score candidate games for /stream?mode=recommend. mu guards every field:
+// a long-lived recommend stream goroutine and a concurrent POST /feedback
+// for the same session both touch these maps, so SessionLRU's own lock
+// (which only protects its bookkeeping) isn't enough on its own.
+type SessionProfile struct {
+	mu sync.Mutex
+
+	GenreCounts     map[string]float64
+	PlatformCounts  map[string]float64
+	PublisherCounts map[string]float64
+	Seen            map[int]bool
+}
+
+func newSessionProfile() *SessionProfile {
+	return &SessionProfile{
+		GenreCounts:     map[string]float64{},
+		PlatformCounts:  map[string]float64{},
+		PublisherCounts: map[string]float64{},
+		Seen:            map[int]bool{},
+	}
+}
+
+func (p *SessionProfile) applySignal(g Game, signal string) {
+	w, ok := signalWeights[signal]
+	if !ok {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	bump := func(m map[string]float64, key string) {
+		if key == "" {
+			return
+		}
+		if v := m[key] + w; v > 0 {
+			m[key] = v
+		} else {
+			m[key] = 0
+		}
+	}
+	bump(p.GenreCounts, g.Genre)
+	bump(p.PlatformCounts, g.Platform)
+	bump(p.PublisherCounts, g.Publisher)
+}
+
+// score computes a weighted cosine-style overlap between g's one-hot
+// (genre, platform, publisher) features and the session's normalized
+// preference vector: each dimension contributes its share of that
+// dimension's total weight, so the result sits in [0, 3]. Callers must
+// hold p.mu.
+func (p *SessionProfile) score(g Game) float64 {
+	return normalizedShare(p.GenreCounts, g.Genre) +
+		normalizedShare(p.PlatformCounts, g.Platform) +
+		normalizedShare(p.PublisherCounts, g.Publisher)
+}
+
+func normalizedShare(counts map[string]float64, key string) float64 {
+	if key == "" {
+		return 0
+	}
+	total := 0.0
+	for _, v := range counts {
+		total += v
+	}
+	if total <= 0 {
+		return 0
+	}
+	return counts[key] / total
+}
+
+// sessionEntry pairs a profile with its LRU list element for O(1) touch.
+type sessionEntry struct {
+	id         string
+	profile    *SessionProfile
+	lastAccess time.Time
+}
+
+// SessionLRU is a bounded, TTL-expiring cache of SessionProfiles keyed by
+// cookie-based session ID. Least-recently-used entries are evicted once the
+// cache is full; entries older than sessionTTL are treated as absent.
+type SessionLRU struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newSessionLRU(capacity int, ttl time.Duration) *SessionLRU {
+	return &SessionLRU{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// GetOrCreate returns the profile for id, creating one if absent or expired.
+func (c *SessionLRU) GetOrCreate(id string) *SessionProfile {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[id]; ok {
+		entry := el.Value.(*sessionEntry)
+		if time.Since(entry.lastAccess) <= c.ttl {
+			entry.lastAccess = time.Now()
+			c.order.MoveToFront(el)
+			return entry.profile
+		}
+		c.order.Remove(el)
+		delete(c.entries, id)
+	}
+
+	entry := &sessionEntry{id: id, profile: newSessionProfile(), lastAccess: time.Now()}
+	el := c.order.PushFront(entry)
+	c.entries[id] = el
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*sessionEntry).id)
+	}
+
+	return entry.profile
+}
+
+var sessions = newSessionLRU(sessionMaxProfiles, sessionTTL)
+
+func newSessionID() string {
+	buf := make([]byte, 16)
+	if _, err := crand.Read(buf); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// sessionIDFromRequest reads the session cookie, minting and setting a new
+// one if it's missing.
+func sessionIDFromRequest(c *gin.Context) string {
+	if id, err := c.Cookie(sessionCookieName); err == nil && id != "" {
+		return id
+	}
+	id := newSessionID()
+	c.SetCookie(sessionCookieName, id, int(sessionTTL.Seconds()), "/", "", false, true)
+	return id
+}
+
+// recommendNext picks the next game for a session: with probability
+// explorationRate it samples uniformly at random (exploration), otherwise
+// it samples from softmax(score/T) over the session's unseen candidates.
+// Once every game has been seen, the seen set resets so the stream can
+// keep going indefinitely.
+func recommendNext(profile *SessionProfile) (Game, bool) {
+	all := store.Games()
+	if len(all) == 0 {
+		return Game{}, false
+	}
+
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	candidates := unseenGames(all, profile.Seen)
+	if len(candidates) == 0 {
+		profile.Seen = map[int]bool{}
+		candidates = all
+	}
+
+	var chosen Game
+	if rand.Float64() < explorationRate {
+		chosen = candidates[rand.Intn(len(candidates))]
+	} else {
+		chosen = weightedSample(candidates, profile)
+	}
+
+	profile.Seen[chosen.ID] = true
+	return chosen, true
+}
+
+func unseenGames(all []Game, seen map[int]bool) []Game {
+	out := make([]Game, 0, len(all))
+	for _, g := range all {
+		if !seen[g.ID] {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// weightedSample draws one game from candidates according to
+// softmax(score/T), computed over this candidate set only. Callers must
+// hold profile.mu.
+func weightedSample(candidates []Game, profile *SessionProfile) Game {
+	weights := make([]float64, len(candidates))
+	maxScore := math.Inf(-1)
+	for i, g := range candidates {
+		s := profile.score(g)
+		weights[i] = s
+		if s > maxScore {
+			maxScore = s
+		}
+	}
+
+	total := 0.0
+	for i, s := range weights {
+		// Subtract maxScore before exponentiating for numerical stability;
+		// it cancels out of the final normalized distribution.
+		weights[i] = math.Exp((s - maxScore) / recommendTemperature)
+		total += weights[i]
+	}
+
+	target := rand.Float64() * total
+	cum := 0.0
+	for i, w := range weights {
+		cum += w
+		if target <= cum {
+			return candidates[i]
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// feedbackRequest is the body accepted by POST /feedback.
+type feedbackRequest struct {
+	ID     int    `json:"id" binding:"required"`
+	Signal string `json:"signal" binding:"required"`
+}
+
+func feedbackHandler(c *gin.Context) {
+	var req feedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if _, ok := signalWeights[req.Signal]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown signal"})
+		return
+	}
+
+	game, ok := store.GetByID(req.ID)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+		return
+	}
+
+	sessionID := sessionIDFromRequest(c)
+	profile := sessions.GetOrCreate(sessionID)
+	profile.applySignal(game, req.Signal)
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
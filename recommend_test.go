@@ -0,0 +1,119 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSessionLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	lru := newSessionLRU(2, time.Hour)
+
+	a := lru.GetOrCreate("a")
+	lru.GetOrCreate("b")
+	lru.GetOrCreate("a") // touch "a" so "b" becomes least-recently-used
+	lru.GetOrCreate("c") // over capacity, should evict "b"
+
+	if _, ok := lru.entries["b"]; ok {
+		t.Errorf("\"b\" should have been evicted as least-recently-used")
+	}
+	if _, ok := lru.entries["a"]; !ok {
+		t.Errorf("\"a\" should still be present, it was touched most recently")
+	}
+	if got := lru.GetOrCreate("a"); got != a {
+		t.Errorf("GetOrCreate(\"a\") returned a different profile after touch, want the same instance")
+	}
+}
+
+func TestSessionLRUExpiresAfterTTL(t *testing.T) {
+	lru := newSessionLRU(10, time.Millisecond)
+
+	first := lru.GetOrCreate("a")
+	first.GenreCounts["shooter"] = 5
+
+	time.Sleep(5 * time.Millisecond)
+
+	second := lru.GetOrCreate("a")
+	if second == first {
+		t.Errorf("expected a fresh profile after TTL expiry, got the same instance")
+	}
+	if len(second.GenreCounts) != 0 {
+		t.Errorf("fresh profile should start empty, got %v", second.GenreCounts)
+	}
+}
+
+func TestNormalizedShare(t *testing.T) {
+	counts := map[string]float64{"shooter": 3, "moba": 1}
+	if got := normalizedShare(counts, "shooter"); math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("normalizedShare(shooter) = %v, want 0.75", got)
+	}
+	if got := normalizedShare(counts, "strategy"); got != 0 {
+		t.Errorf("normalizedShare(unseen key) = %v, want 0", got)
+	}
+	if got := normalizedShare(map[string]float64{}, "shooter"); got != 0 {
+		t.Errorf("normalizedShare over empty counts = %v, want 0", got)
+	}
+}
+
+func TestApplySignalClampsAtZero(t *testing.T) {
+	p := newSessionProfile()
+	p.applySignal(Game{Genre: "shooter"}, "dislike")
+	p.applySignal(Game{Genre: "shooter"}, "dislike")
+
+	if got := p.GenreCounts["shooter"]; got != 0 {
+		t.Errorf("GenreCounts[shooter] = %v, want 0 (clamped, not negative)", got)
+	}
+}
+
+func TestWeightedSampleFavorsHigherScoringCandidate(t *testing.T) {
+	profile := newSessionProfile()
+	profile.GenreCounts["shooter"] = 10
+	profile.GenreCounts["puzzle"] = 1
+
+	shooterGame := Game{ID: 1, Genre: "shooter"}
+	puzzleGame := Game{ID: 2, Genre: "puzzle"}
+	candidates := []Game{shooterGame, puzzleGame}
+
+	profile.mu.Lock()
+	defer profile.mu.Unlock()
+
+	shooterWins := 0
+	const trials = 2000
+	for i := 0; i < trials; i++ {
+		if weightedSample(candidates, profile).ID == shooterGame.ID {
+			shooterWins++
+		}
+	}
+
+	// With a 10:1 preference skew and T=0.7, the shooter game should win
+	// comfortably more often than chance (50%) but this is a randomized
+	// sample, so assert a loose majority rather than an exact ratio.
+	if shooterWins < trials*6/10 {
+		t.Errorf("shooter game won %d/%d draws, want a clear majority given its higher score", shooterWins, trials)
+	}
+}
+
+func TestRecommendNextResetsSeenOnceExhausted(t *testing.T) {
+	original := store
+	store = &GameStore{}
+	store.SetGames([]Game{{ID: 1, Title: "A"}, {ID: 2, Title: "B"}})
+	defer func() { store = original }()
+
+	profile := newSessionProfile()
+	first, ok := recommendNext(profile)
+	if !ok {
+		t.Fatalf("recommendNext returned ok=false with games in store")
+	}
+	second, ok := recommendNext(profile)
+	if !ok {
+		t.Fatalf("recommendNext returned ok=false with games in store")
+	}
+	if first.ID == second.ID {
+		t.Fatalf("recommendNext repeated %d before exhausting the unseen set", first.ID)
+	}
+
+	// Both games are now seen; the next call must reset rather than fail.
+	if _, ok := recommendNext(profile); !ok {
+		t.Fatalf("recommendNext should reset the seen set and keep producing games, got ok=false")
+	}
+}
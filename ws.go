@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+const clientSendBuffer = 16
+
+// wsFilters narrows the games a Client receives on /ws.
+type wsFilters struct {
+	Genre    string `json:"genre"`
+	Platform string `json:"platform"`
+}
+
+// wsControlMessage is a control frame sent by the client over /ws.
+type wsControlMessage struct {
+	Op      string    `json:"op"`
+	Filters wsFilters `json:"filters"`
+	RateMs  int       `json:"rate_ms"`
+	ID      int       `json:"id"`
+}
+
+// Client is a single /ws connection: a reader goroutine applies control
+// messages, a writer goroutine drains send on a per-client cadence.
+type Client struct {
+	hub     *Hub
+	conn    *websocket.Conn
+	send    chan []byte
+	refresh chan struct{}
+	done    chan struct{}
+
+	mu      sync.Mutex
+	filters wsFilters
+	rate    time.Duration
+	paused  bool
+	seen    map[int]bool
+}
+
+func newClient(hub *Hub, conn *websocket.Conn) *Client {
+	return &Client{
+		hub:     hub,
+		conn:    conn,
+		send:    make(chan []byte, clientSendBuffer),
+		refresh: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		rate:    3 * time.Second,
+		seen:    make(map[int]bool),
+	}
+}
+
+// enqueue pushes a payload to the client's send buffer, dropping the oldest
+// queued message instead of blocking a slow consumer.
+func (c *Client) enqueue(payload []byte) {
+	select {
+	case c.send <- payload:
+	default:
+		select {
+		case <-c.send:
+		default:
+		}
+		select {
+		case c.send <- payload:
+		default:
+		}
+	}
+}
+
+func (c *Client) nextGame() {
+	c.mu.Lock()
+	if c.paused {
+		c.mu.Unlock()
+		return
+	}
+	filters := c.filters
+	seen := make(map[int]bool, len(c.seen))
+	for id := range c.seen {
+		seen[id] = true
+	}
+	c.mu.Unlock()
+
+	// seen is a snapshot copy taken under c.mu, so GetRandomFiltered can
+	// range over it without racing readPump's concurrent writes to c.seen.
+	game, ok := store.GetRandomFiltered(filters, seen)
+	if !ok {
+		return
+	}
+	data, err := json.Marshal(game)
+	if err != nil {
+		return
+	}
+	c.enqueue(data)
+}
+
+// writePump owns c.send for its entire life: it's the only goroutine that
+// reads from it, and the only one that closes it, so closing can never race
+// a concurrent enqueue. readPump signals c.done to ask it to stop instead of
+// closing c.send itself.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.currentRate())
+	defer ticker.Stop()
+	defer c.conn.Close()
+	defer close(c.send)
+
+	for {
+		select {
+		case payload := <-c.send:
+			if err := c.conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				return
+			}
+		case <-ticker.C:
+			c.nextGame()
+		case <-c.refresh:
+			c.nextGame()
+		case <-c.done:
+			return
+		}
+
+		if rate := c.currentRate(); rate != 0 {
+			ticker.Reset(rate)
+		}
+	}
+}
+
+func (c *Client) currentRate() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rate
+}
+
+func (c *Client) readPump() {
+	defer c.hub.unregister(c)
+	defer c.conn.Close()
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg wsControlMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			continue
+		}
+
+		switch msg.Op {
+		case "subscribe":
+			c.mu.Lock()
+			c.filters = msg.Filters
+			if msg.RateMs > 0 {
+				c.rate = time.Duration(msg.RateMs) * time.Millisecond
+			}
+			c.mu.Unlock()
+		case "pause":
+			c.mu.Lock()
+			c.paused = true
+			c.mu.Unlock()
+		case "resume":
+			c.mu.Lock()
+			c.paused = false
+			c.mu.Unlock()
+		case "seen":
+			c.mu.Lock()
+			c.seen[msg.ID] = true
+			c.mu.Unlock()
+		}
+	}
+}
+
+// Hub tracks connected /ws clients and fans out store-refresh events so
+// clients can react immediately instead of waiting for their next tick.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[*Client]bool
+}
+
+func newHub() *Hub {
+	return &Hub{
+		clients: make(map[*Client]bool),
+	}
+}
+
+func (h *Hub) register(c *Client) {
+	h.mu.Lock()
+	h.clients[c] = true
+	h.mu.Unlock()
+}
+
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		close(c.done)
+	}
+	h.mu.Unlock()
+}
+
+// BroadcastRefresh notifies every connected client that the store changed.
+// Sends are non-blocking: a client mid-tick simply picks it up next loop.
+func (h *Hub) BroadcastRefresh() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		select {
+		case c.refresh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+var hub = newHub()
+
+func wsHandler(c *gin.Context) {
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		slog.Warn("ws upgrade failed", "error", err)
+		return
+	}
+
+	client := newClient(hub, conn)
+	hub.register(client)
+
+	go client.writePump()
+	client.readPump()
+}
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestClientEnqueueDropsOldestWhenFull(t *testing.T) {
+	c := &Client{send: make(chan []byte, 2)}
+	c.enqueue([]byte("1"))
+	c.enqueue([]byte("2"))
+	c.enqueue([]byte("3")) // buffer is full; should drop "1", not "3"
+
+	first := <-c.send
+	second := <-c.send
+	if string(first) != "2" || string(second) != "3" {
+		t.Fatalf("enqueue kept %q, %q; want the 2 most recent (\"2\", \"3\") under backpressure", first, second)
+	}
+	select {
+	case extra := <-c.send:
+		t.Fatalf("unexpected third queued message %q", extra)
+	default:
+	}
+}
+
+func TestClientEnqueueUnderCapacityKeepsAll(t *testing.T) {
+	c := &Client{send: make(chan []byte, 4)}
+	c.enqueue([]byte("a"))
+	c.enqueue([]byte("b"))
+
+	if got := <-c.send; string(got) != "a" {
+		t.Fatalf("first dequeued = %q, want %q", got, "a")
+	}
+	if got := <-c.send; string(got) != "b" {
+		t.Fatalf("second dequeued = %q, want %q", got, "b")
+	}
+}